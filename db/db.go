@@ -0,0 +1,59 @@
+// Package db owns the process-wide Postgres connection and the models
+// built on top of it.
+package db
+
+import (
+	"context"
+
+	"github.com/rockswe/justtodothings/internal/logging"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// DB is the shared connection pool. It is populated by ConnectDatabase and
+// read by every model in this package.
+var DB *gorm.DB
+
+// ConnectDatabase opens the Postgres connection pool for dsn, verifies it
+// with a ping bound to ctx, and assigns it to DB. It fatals on failure,
+// via the logger carried on ctx, since the server cannot serve requests
+// without a database.
+func ConnectDatabase(ctx context.Context, dsn string) {
+	entry := logging.FromContext(ctx)
+
+	database, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	if err != nil {
+		entry.Fatalf("failed to connect to database: %v", err)
+	}
+
+	sqlDB, err := database.DB()
+	if err != nil {
+		entry.Fatalf("failed to access underlying sql.DB: %v", err)
+	}
+
+	if err := sqlDB.PingContext(ctx); err != nil {
+		entry.Fatalf("failed to ping database: %v", err)
+	}
+
+	if err := database.AutoMigrate(&User{}, &RevokedToken{}, &RefreshToken{}, &Todo{}); err != nil {
+		entry.Fatalf("failed to run migrations: %v", err)
+	}
+
+	DB = database
+}
+
+// Close releases the underlying connection pool. It is safe to call even
+// if ConnectDatabase was never called.
+func Close() error {
+	if DB == nil {
+		return nil
+	}
+
+	sqlDB, err := DB.DB()
+	if err != nil {
+		return err
+	}
+
+	return sqlDB.Close()
+}
@@ -0,0 +1,81 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Todo is a single task belonging to a User, optionally carrying a
+// due-date reminder dispatched by internal/reminders.
+type Todo struct {
+	gorm.Model
+	UserID         uint
+	OwnerEmail     string
+	Title          string
+	RemindAt       *time.Time
+	ReminderSentAt *time.Time
+	FailedAttempts int
+	NextAttemptAt  *time.Time
+	DeadLettered   bool
+}
+
+// ClaimDueReminders atomically locks up to limit todos whose reminder is
+// due, unsent, not dead-lettered, and not waiting out a retry backoff,
+// using SELECT ... FOR UPDATE SKIP LOCKED so multiple dispatcher replicas
+// never pick the same row. The row locks only hold for the lifetime of
+// the transaction, so before committing it stamps next_attempt_at to
+// now+leaseFor on every claimed row, pushing them out of the candidate
+// set for the rest of the lease. If the claiming replica dies before
+// marking the row sent or scheduling a real retry, the lease simply
+// expires and another replica can pick it up.
+func ClaimDueReminders(ctx context.Context, limit int, leaseFor time.Duration) ([]Todo, error) {
+	var todos []Todo
+	now := time.Now()
+
+	err := DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("remind_at IS NOT NULL AND remind_at <= ? AND reminder_sent_at IS NULL AND dead_lettered = ? AND (next_attempt_at IS NULL OR next_attempt_at <= ?)", now, false, now).
+			Order("remind_at").
+			Limit(limit).
+			Find(&todos).Error; err != nil {
+			return err
+		}
+
+		if len(todos) == 0 {
+			return nil
+		}
+
+		ids := make([]uint, len(todos))
+		for i, t := range todos {
+			ids[i] = t.ID
+		}
+
+		return tx.Model(&Todo{}).Where("id IN ?", ids).Update("next_attempt_at", now.Add(leaseFor)).Error
+	})
+
+	return todos, err
+}
+
+// MarkReminderSent records that a todo's reminder was delivered.
+func MarkReminderSent(id uint) error {
+	now := time.Now()
+	return DB.Model(&Todo{}).Where("id = ?", id).Update("reminder_sent_at", &now).Error
+}
+
+// ScheduleReminderRetry bumps the failure count and schedules the next
+// attempt after an exponential backoff.
+func ScheduleReminderRetry(id uint, failedAttempts int, nextAttempt time.Time) error {
+	return DB.Model(&Todo{}).Where("id = ?", id).Updates(map[string]any{
+		"failed_attempts": failedAttempts,
+		"next_attempt_at": nextAttempt,
+	}).Error
+}
+
+// MarkReminderDead moves a todo into the dead-letter state after it has
+// exhausted its retry budget.
+func MarkReminderDead(id uint) error {
+	return DB.Model(&Todo{}).Where("id = ?", id).Update("dead_lettered", true).Error
+}
@@ -0,0 +1,52 @@
+package db
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// RevokedToken records a JWT ID (jti) that has been logged out of, so
+// auth.Required can reject it even though it has not yet expired.
+type RevokedToken struct {
+	JTI       string `gorm:"primaryKey"`
+	ExpiresAt time.Time
+}
+
+// RevokeToken blacklists jti until it would have expired anyway. It's
+// idempotent: a second logout with the same still-valid access token (or
+// two racing logout requests) hits the same primary key and is treated
+// as already revoked rather than a failure.
+func RevokeToken(jti string, expiresAt time.Time) error {
+	return DB.Clauses(clause.OnConflict{DoNothing: true}).Create(&RevokedToken{JTI: jti, ExpiresAt: expiresAt}).Error
+}
+
+// IsTokenRevoked reports whether jti has been blacklisted.
+func IsTokenRevoked(jti string) (bool, error) {
+	var count int64
+	if err := DB.Model(&RevokedToken{}).Where("jti = ?", jti).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// RevokeRefreshTokensForUser revokes every outstanding refresh token for
+// userID, so logging out actually ends the session instead of leaving a
+// refresh token that can mint fresh access tokens after the access token
+// is blacklisted.
+func RevokeRefreshTokensForUser(userID uint) error {
+	return DB.Model(&RefreshToken{}).Where("user_id = ? AND revoked = ?", userID, false).Update("revoked", true).Error
+}
+
+// RefreshToken lets a client exchange a long-lived token for a new access
+// token without re-authenticating with a password. TokenHash is a sha256
+// digest of the opaque token handed to the client, not a bcrypt hash,
+// since it is high-entropy and random rather than user-chosen.
+type RefreshToken struct {
+	gorm.Model
+	UserID    uint `gorm:"index;not null"`
+	TokenHash string `gorm:"uniqueIndex;not null"`
+	ExpiresAt time.Time
+	Revoked   bool
+}
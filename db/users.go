@@ -0,0 +1,30 @@
+package db
+
+import "gorm.io/gorm"
+
+// User is an application account that owns todos and authenticates with
+// an email and bcrypt-hashed password.
+type User struct {
+	gorm.Model
+	Email        string `gorm:"uniqueIndex;not null"`
+	PasswordHash string `gorm:"not null"`
+}
+
+// CreateUser inserts a new user with the given email and bcrypt hash.
+func CreateUser(email, passwordHash string) (*User, error) {
+	user := &User{Email: email, PasswordHash: passwordHash}
+	if err := DB.Create(user).Error; err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// FindUserByEmail looks up a user by email, returning gorm.ErrRecordNotFound
+// if no account exists.
+func FindUserByEmail(email string) (*User, error) {
+	var user User
+	if err := DB.Where("email = ?", email).First(&user).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
@@ -0,0 +1,69 @@
+// Package auth issues and validates the HS256 JWTs todos are scoped by,
+// and provides the Gin handlers and middleware that sit in front of it.
+package auth
+
+import (
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+// ErrInvalidToken is returned for any token that fails to parse, has an
+// invalid signature, or has expired.
+var ErrInvalidToken = errors.New("auth: invalid or expired token")
+
+// Claims are the JWT claims issued for an authenticated user.
+type Claims struct {
+	UserID uint `json:"user_id"`
+	jwt.RegisteredClaims
+}
+
+// Issuer signs and verifies access tokens with a single HMAC secret.
+type Issuer struct {
+	secret []byte
+	ttl    time.Duration
+}
+
+// NewIssuer returns an Issuer that signs tokens with secret and sets them
+// to expire after ttl.
+func NewIssuer(secret string, ttl time.Duration) *Issuer {
+	return &Issuer{secret: []byte(secret), ttl: ttl}
+}
+
+// Issue mints a signed access token for userID, returning the token and
+// the JTI it was issued with so callers can blacklist it later on logout.
+func (i *Issuer) Issue(userID uint) (token string, jti string, err error) {
+	now := time.Now()
+	jti = uuid.NewString()
+
+	claims := Claims{
+		UserID: userID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        jti,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(i.ttl)),
+		},
+	}
+
+	token, err = jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(i.secret)
+	return token, jti, err
+}
+
+// Parse validates tokenString's signature and expiry and returns its claims.
+func (i *Issuer) Parse(tokenString string) (*Claims, error) {
+	claims := &Claims{}
+
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, ErrInvalidToken
+		}
+		return i.secret, nil
+	})
+	if err != nil || !token.Valid {
+		return nil, ErrInvalidToken
+	}
+
+	return claims, nil
+}
@@ -0,0 +1,171 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"time"
+
+	"github.com/rockswe/justtodothings/db"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type credentials struct {
+	Email    string `json:"email" binding:"required,email"`
+	Password string `json:"password" binding:"required,min=8"`
+}
+
+type refreshRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// RegisterHandler creates a new account and returns a fresh token pair.
+func RegisterHandler(issuer *Issuer, refreshTTL time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var creds credentials
+		if err := c.ShouldBindJSON(&creds); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		hash, err := bcrypt.GenerateFromPassword([]byte(creds.Password), bcrypt.DefaultCost)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to hash password"})
+			return
+		}
+
+		user, err := db.CreateUser(creds.Email, string(hash))
+		if err != nil {
+			c.JSON(http.StatusConflict, gin.H{"error": "email already registered"})
+			return
+		}
+
+		issueTokenPair(c, issuer, refreshTTL, user.ID)
+	}
+}
+
+// LoginHandler verifies the password and returns a fresh token pair.
+func LoginHandler(issuer *Issuer, refreshTTL time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var creds credentials
+		if err := c.ShouldBindJSON(&creds); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		user, err := db.FindUserByEmail(creds.Email)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid email or password"})
+			return
+		}
+
+		if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(creds.Password)); err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid email or password"})
+			return
+		}
+
+		issueTokenPair(c, issuer, refreshTTL, user.ID)
+	}
+}
+
+// RefreshHandler exchanges a still-valid refresh token for a new token
+// pair, revoking the one it was given so each refresh token is single-use
+// (rotation).
+func RefreshHandler(issuer *Issuer, refreshTTL time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req refreshRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		var stored db.RefreshToken
+		err := db.DB.Where("token_hash = ? AND revoked = ?", hashToken(req.RefreshToken), false).First(&stored).Error
+		if err != nil || time.Now().After(stored.ExpiresAt) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid or expired refresh token"})
+			return
+		}
+
+		res := db.DB.Model(&stored).Where("revoked = ?", false).Update("revoked", true)
+		if res.Error != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to rotate refresh token"})
+			return
+		}
+		if res.RowsAffected == 0 {
+			// A concurrent refresh already redeemed this token.
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid or expired refresh token"})
+			return
+		}
+
+		issueTokenPair(c, issuer, refreshTTL, stored.UserID)
+	}
+}
+
+// LogoutHandler blacklists the calling request's access token so it can
+// no longer be used even though it has not yet expired, and revokes the
+// caller's outstanding refresh tokens so a held refresh token can't mint
+// a fresh pair right after logout. It must sit behind Required, which
+// populates userID/tokenJTI/tokenExpiry.
+func LogoutHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		jti, _ := c.Get("tokenJTI")
+		jtiStr, _ := jti.(string)
+		if jtiStr != "" {
+			expiresAt, _ := c.Get("tokenExpiry")
+			exp, _ := expiresAt.(time.Time)
+
+			if err := db.RevokeToken(jtiStr, exp); err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to revoke token"})
+				return
+			}
+		}
+
+		if userID, ok := c.Get("userID"); ok {
+			if id, ok := userID.(uint); ok {
+				if err := db.RevokeRefreshTokensForUser(id); err != nil {
+					c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to revoke refresh tokens"})
+					return
+				}
+			}
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "logged out"})
+	}
+}
+
+// issueTokenPair mints an access token via issuer and a random opaque
+// refresh token, persists the refresh token's hash, and writes both to
+// the response.
+func issueTokenPair(c *gin.Context, issuer *Issuer, refreshTTL time.Duration, userID uint) {
+	access, _, err := issuer.Issue(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to issue access token"})
+		return
+	}
+
+	refresh := uuid.NewString()
+	record := db.RefreshToken{
+		UserID:    userID,
+		TokenHash: hashToken(refresh),
+		ExpiresAt: time.Now().Add(refreshTTL),
+	}
+	if err := db.DB.Create(&record).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to persist refresh token"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"access_token":  access,
+		"refresh_token": refresh,
+	})
+}
+
+// hashToken digests an opaque refresh token so the database never stores
+// the bearer value itself, mirroring how passwords are never stored raw.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
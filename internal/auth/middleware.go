@@ -0,0 +1,42 @@
+package auth
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/rockswe/justtodothings/db"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Required returns Gin middleware that validates the Authorization header
+// against issuer, aborting with 401 on any failure. On success it stores
+// the authenticated user ID as "userID" and the token's JTI/expiry as
+// "tokenJTI"/"tokenExpiry" so LogoutHandler can blacklist it later.
+func Required(issuer *Issuer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := c.GetHeader("Authorization")
+		parts := strings.SplitN(header, " ", 2)
+		if len(parts) != 2 || !strings.EqualFold(parts[0], "Bearer") {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "missing or malformed authorization header"})
+			return
+		}
+
+		claims, err := issuer.Parse(parts[1])
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid or expired token"})
+			return
+		}
+
+		revoked, err := db.IsTokenRevoked(claims.ID)
+		if err != nil || revoked {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "token has been revoked"})
+			return
+		}
+
+		c.Set("userID", claims.UserID)
+		c.Set("tokenJTI", claims.ID)
+		c.Set("tokenExpiry", claims.ExpiresAt.Time)
+		c.Next()
+	}
+}
@@ -0,0 +1,155 @@
+// Package config loads and validates the server's runtime settings so a
+// misconfigured deploy fails at startup with a clear message instead of
+// surfacing as a confusing error deep in a handler.
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+)
+
+const (
+	defaultListen                 = ":8080"
+	defaultLogLevel               = "info"
+	defaultLogFormat              = "text"
+	defaultGinMode                = "release"
+	defaultShutdownTimeout        = 5 * time.Second
+	defaultJWTTTL                 = 15 * time.Minute
+	defaultRefreshTTL             = 30 * 24 * time.Hour
+	defaultRateLimit              = 100
+	defaultRateLimitWindow        = time.Minute
+	defaultReminderTick           = 30 * time.Second
+	defaultReminderBatchSize      = 50
+	defaultReminderMaxAttempts    = 5
+	defaultReminderNotifier       = "email"
+	defaultReminderClaimLease     = 5 * time.Minute
+	defaultReminderWebhookTimeout = 10 * time.Second
+)
+
+// Config holds every runtime setting the server needs.
+type Config struct {
+	Listen                 string
+	DatabaseURL            string
+	JWTSecret              string
+	JWTTTL                 time.Duration
+	RefreshTTL             time.Duration
+	AllowedOrigins         []string
+	ContentSecurityPolicy  string
+	RateLimit              int
+	RateLimitWindow        time.Duration
+	LogLevel               string
+	LogFormat              string
+	GinMode                string
+	ShutdownTimeout        time.Duration
+	ReminderTickInterval   time.Duration
+	ReminderBatchSize      int
+	ReminderMaxAttempts    int
+	ReminderClaimLease     time.Duration
+	ReminderNotifier       string
+	SMTPAddr               string
+	SMTPFrom               string
+	ReminderWebhookURL     string
+	ReminderWebhookTimeout time.Duration
+}
+
+// Load reads .env (if present), overlays the real process environment on
+// top of it, applies defaults, and returns a populated Config. Every
+// missing or malformed required setting is collected into a single error
+// so a misconfigured deploy can be fixed in one pass instead of one
+// log.Fatal at a time.
+func Load() (*Config, error) {
+	// .env is optional in production, where real env vars are set by the
+	// deploy environment, so its absence is not an error.
+	_ = godotenv.Load()
+
+	var problems []string
+
+	cfg := &Config{
+		DatabaseURL:           requireEnv("DATABASE_URL", &problems),
+		JWTSecret:             requireEnv("JWT_SECRET", &problems),
+		Listen:                envOrDefault("LISTEN_ADDR", defaultListen),
+		LogLevel:              envOrDefault("LOG_LEVEL", defaultLogLevel),
+		LogFormat:             envOrDefault("LOG_FORMAT", defaultLogFormat),
+		GinMode:               envOrDefault("GIN_MODE", defaultGinMode),
+		ContentSecurityPolicy: os.Getenv("CONTENT_SECURITY_POLICY"),
+		ReminderNotifier:      envOrDefault("REMINDER_NOTIFIER", defaultReminderNotifier),
+		SMTPAddr:              os.Getenv("SMTP_ADDR"),
+		SMTPFrom:              os.Getenv("SMTP_FROM"),
+		ReminderWebhookURL:    os.Getenv("REMINDER_WEBHOOK_URL"),
+	}
+
+	if origins := os.Getenv("ALLOWED_ORIGINS"); origins != "" {
+		cfg.AllowedOrigins = strings.Split(origins, ",")
+	}
+
+	cfg.ShutdownTimeout = parseDuration("SHUTDOWN_TIMEOUT", defaultShutdownTimeout, &problems)
+	cfg.JWTTTL = parseDuration("JWT_TTL", defaultJWTTTL, &problems)
+	cfg.RefreshTTL = parseDuration("REFRESH_TTL", defaultRefreshTTL, &problems)
+	cfg.RateLimit = parseInt("RATE_LIMIT", defaultRateLimit, &problems)
+	cfg.RateLimitWindow = parseDuration("RATE_LIMIT_WINDOW", defaultRateLimitWindow, &problems)
+	cfg.ReminderTickInterval = parseDuration("REMINDER_TICK_INTERVAL", defaultReminderTick, &problems)
+	cfg.ReminderBatchSize = parseInt("REMINDER_BATCH_SIZE", defaultReminderBatchSize, &problems)
+	cfg.ReminderMaxAttempts = parseInt("REMINDER_MAX_ATTEMPTS", defaultReminderMaxAttempts, &problems)
+	cfg.ReminderClaimLease = parseDuration("REMINDER_CLAIM_LEASE", defaultReminderClaimLease, &problems)
+	cfg.ReminderWebhookTimeout = parseDuration("REMINDER_WEBHOOK_TIMEOUT", defaultReminderWebhookTimeout, &problems)
+
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("config: %s", strings.Join(problems, "; "))
+	}
+
+	return cfg, nil
+}
+
+func requireEnv(key string, problems *[]string) string {
+	v := os.Getenv(key)
+	if v == "" {
+		*problems = append(*problems, fmt.Sprintf("%s is required", key))
+	}
+	return v
+}
+
+func envOrDefault(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
+
+// parseDuration reads key as a time.Duration, falling back to fallback
+// when unset and recording a problem when set but malformed.
+func parseDuration(key string, fallback time.Duration, problems *[]string) time.Duration {
+	v := os.Getenv(key)
+	if v == "" {
+		return fallback
+	}
+
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		*problems = append(*problems, fmt.Sprintf("%s is not a valid duration: %v", key, err))
+		return fallback
+	}
+
+	return d
+}
+
+// parseInt reads key as an int, falling back to fallback when unset and
+// recording a problem when set but malformed.
+func parseInt(key string, fallback int, problems *[]string) int {
+	v := os.Getenv(key)
+	if v == "" {
+		return fallback
+	}
+
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		*problems = append(*problems, fmt.Sprintf("%s is not a valid integer: %v", key, err))
+		return fallback
+	}
+
+	return n
+}
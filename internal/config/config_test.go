@@ -0,0 +1,72 @@
+package config
+
+import (
+	"os"
+	"testing"
+)
+
+func clearEnv() {
+	for _, key := range []string{
+		"DATABASE_URL", "JWT_SECRET", "LISTEN_ADDR", "LOG_LEVEL",
+		"LOG_FORMAT", "GIN_MODE", "ALLOWED_ORIGINS", "SHUTDOWN_TIMEOUT",
+		"JWT_TTL", "REFRESH_TTL", "CONTENT_SECURITY_POLICY", "RATE_LIMIT",
+		"RATE_LIMIT_WINDOW", "REMINDER_NOTIFIER", "SMTP_ADDR", "SMTP_FROM",
+		"REMINDER_WEBHOOK_URL", "REMINDER_TICK_INTERVAL", "REMINDER_BATCH_SIZE",
+		"REMINDER_MAX_ATTEMPTS", "REMINDER_CLAIM_LEASE", "REMINDER_WEBHOOK_TIMEOUT",
+	} {
+		os.Unsetenv(key)
+	}
+}
+
+func TestLoad_MissingRequiredVars(t *testing.T) {
+	clearEnv()
+
+	_, err := Load()
+	if err == nil {
+		t.Fatal("expected an error when DATABASE_URL and JWT_SECRET are unset")
+	}
+}
+
+func TestLoad_MalformedShutdownTimeout(t *testing.T) {
+	clearEnv()
+	defer clearEnv()
+
+	os.Setenv("DATABASE_URL", "postgres://localhost/test")
+	os.Setenv("JWT_SECRET", "secret")
+	os.Setenv("SHUTDOWN_TIMEOUT", "not-a-duration")
+
+	_, err := Load()
+	if err == nil {
+		t.Fatal("expected an error for a malformed SHUTDOWN_TIMEOUT")
+	}
+}
+
+func TestLoad_OverridesFromEnv(t *testing.T) {
+	clearEnv()
+	defer clearEnv()
+
+	os.Setenv("DATABASE_URL", "postgres://localhost/test")
+	os.Setenv("JWT_SECRET", "secret")
+	os.Setenv("LISTEN_ADDR", ":9090")
+	os.Setenv("LOG_LEVEL", "debug")
+	os.Setenv("ALLOWED_ORIGINS", "https://a.test,https://b.test")
+	os.Setenv("SHUTDOWN_TIMEOUT", "10s")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.Listen != ":9090" {
+		t.Errorf("Listen = %q, want %q", cfg.Listen, ":9090")
+	}
+	if cfg.LogLevel != "debug" {
+		t.Errorf("LogLevel = %q, want %q", cfg.LogLevel, "debug")
+	}
+	if len(cfg.AllowedOrigins) != 2 {
+		t.Errorf("AllowedOrigins = %v, want 2 entries", cfg.AllowedOrigins)
+	}
+	if cfg.ShutdownTimeout.String() != "10s" {
+		t.Errorf("ShutdownTimeout = %v, want 10s", cfg.ShutdownTimeout)
+	}
+}
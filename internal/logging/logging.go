@@ -0,0 +1,56 @@
+// Package logging configures the process-wide logrus logger and carries a
+// per-request logrus.Entry (tagged with a correlation ID) through
+// context.Context so handlers and the db package can log with the same
+// correlation ID as the request that triggered them.
+package logging
+
+import (
+	"context"
+	"os"
+
+	"github.com/sirupsen/logrus"
+)
+
+// New configures logrus's standard logger from level ("debug", "info",
+// ...) and format ("text" or "json"), defaulting to info/text on invalid
+// input, and returns it. Using the standard logger means FromContext's
+// fallback entry (for code running outside a request) shares the same
+// configuration.
+func New(level, format string) *logrus.Logger {
+	logger := logrus.StandardLogger()
+	logger.SetOutput(os.Stdout)
+
+	parsedLevel, err := logrus.ParseLevel(level)
+	if err != nil {
+		parsedLevel = logrus.InfoLevel
+	}
+	logger.SetLevel(parsedLevel)
+
+	if format == "json" {
+		logger.SetFormatter(&logrus.JSONFormatter{})
+	} else {
+		logger.SetFormatter(&logrus.TextFormatter{FullTimestamp: true})
+	}
+
+	return logger
+}
+
+type contextKey int
+
+const entryKey contextKey = iota
+
+// WithEntry attaches entry to ctx so downstream code can log with the same
+// correlation fields as the request that triggered it.
+func WithEntry(ctx context.Context, entry *logrus.Entry) context.Context {
+	return context.WithValue(ctx, entryKey, entry)
+}
+
+// FromContext returns the logrus.Entry stashed by RequestLogger, or a bare
+// entry on the standard logger if ctx carries none (e.g. startup code
+// running before any request).
+func FromContext(ctx context.Context) *logrus.Entry {
+	if entry, ok := ctx.Value(entryKey).(*logrus.Entry); ok {
+		return entry
+	}
+	return logrus.NewEntry(logrus.StandardLogger())
+}
@@ -0,0 +1,42 @@
+package logging
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// RequestLogger returns Gin middleware that generates or propagates an
+// X-Request-ID header, attaches a logrus.Entry carrying it to the request
+// context, and emits one structured line per request with method, path,
+// status, latency, bytes, and the authenticated user ID when present.
+func RequestLogger(logger *logrus.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader("X-Request-ID")
+		if requestID == "" {
+			requestID = uuid.NewString()
+		}
+		c.Header("X-Request-ID", requestID)
+
+		entry := logger.WithField("request_id", requestID)
+		c.Request = c.Request.WithContext(WithEntry(c.Request.Context(), entry))
+
+		start := time.Now()
+		c.Next()
+
+		fields := logrus.Fields{
+			"method":  c.Request.Method,
+			"path":    c.Request.URL.Path,
+			"status":  c.Writer.Status(),
+			"latency": time.Since(start).String(),
+			"bytes":   c.Writer.Size(),
+		}
+		if userID, ok := c.Get("userID"); ok {
+			fields["user_id"] = userID
+		}
+
+		entry.WithFields(fields).Info("request handled")
+	}
+}
@@ -0,0 +1,37 @@
+// Package middleware holds the cross-cutting Gin middleware (CORS,
+// security headers, rate limiting) registered before any route so every
+// response gets them, authenticated or not.
+package middleware
+
+import (
+	"time"
+
+	"github.com/gin-contrib/cors"
+	"github.com/gin-gonic/gin"
+)
+
+// CORS returns a gin-contrib/cors middleware scoped to allowedOrigins,
+// with credentials allowed and a long preflight cache so browsers don't
+// re-run OPTIONS on every request.
+//
+// cors.New panics at startup if AllowAllOrigins is false, AllowOriginFunc
+// is nil, and AllowOrigins is empty ("no origin is allowed"). A bare
+// deploy with ALLOWED_ORIGINS unset would hit exactly that, so an empty
+// allowedOrigins fails closed via AllowOriginFunc instead of crashing the
+// server.
+func CORS(allowedOrigins []string) gin.HandlerFunc {
+	cfg := cors.Config{
+		AllowOrigins:     allowedOrigins,
+		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization"},
+		AllowCredentials: true,
+		MaxAge:           12 * time.Hour,
+	}
+
+	if len(allowedOrigins) == 0 {
+		cfg.AllowOrigins = nil
+		cfg.AllowOriginFunc = func(origin string) bool { return false }
+	}
+
+	return cors.New(cfg)
+}
@@ -0,0 +1,134 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LimiterStore decides, per key, whether a request fits within limit
+// requests per window. The in-memory token-bucket implementation below is the default for a single instance;
+// swap in a Redis-backed LimiterStore for multi-instance deployments
+// without touching call sites.
+type LimiterStore interface {
+	// Allow reports whether one more request for key is permitted under
+	// limit within window, counting this call toward the total.
+	Allow(key string, limit int, window time.Duration) bool
+}
+
+// RateLimitConfig configures a single route's (or the global) limit. A
+// zero Limit disables limiting.
+type RateLimitConfig struct {
+	Limit  int
+	Window time.Duration
+}
+
+// RateLimiter returns middleware enforcing cfg against store, keyed by the
+// authenticated user ID when Required has already run, falling back to
+// the client IP otherwise. scope namespaces the key so separate
+// RateLimiter registrations (e.g. a global limiter and a tighter
+// per-route override) never share the same counter in store.
+func RateLimiter(store LimiterStore, scope string, cfg RateLimitConfig) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if cfg.Limit <= 0 {
+			c.Next()
+			return
+		}
+
+		client := c.ClientIP()
+		if userID, ok := c.Get("userID"); ok {
+			client = fmt.Sprintf("user:%v", userID)
+		}
+		key := scope + ":" + client
+
+		if !store.Allow(key, cfg.Limit, cfg.Window) {
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "rate limit exceeded"})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// DefaultStore returns the package's in-memory LimiterStore.
+func DefaultStore() LimiterStore {
+	return &memoryStore{buckets: make(map[string]*bucket)}
+}
+
+// bucket is a token bucket refilled continuously at limit/window tokens
+// per second, rather than a fixed-window counter: a fixed window lets a
+// client burst up to 2x limit across a window boundary (limit requests
+// just before it resets, limit more just after).
+type bucket struct {
+	tokens     float64
+	limit      float64
+	refillRate float64 // tokens per second
+	lastRefill time.Time
+	lastSeen   time.Time
+}
+
+// staleAfter bounds how long an idle bucket is kept before it's evicted,
+// so a public endpoint hammered by many distinct client IPs doesn't grow
+// memoryStore's map without bound.
+const staleAfter = 10 * time.Minute
+
+type memoryStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+func (s *memoryStore) Allow(key string, limit int, window time.Duration) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	s.evictStale(now)
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{
+			tokens:     float64(limit),
+			limit:      float64(limit),
+			refillRate: float64(limit) / window.Seconds(),
+			lastRefill: now,
+		}
+		s.buckets[key] = b
+	} else {
+		elapsed := now.Sub(b.lastRefill).Seconds()
+		b.tokens = minFloat(b.limit, b.tokens+elapsed*b.refillRate)
+		b.lastRefill = now
+	}
+	b.lastSeen = now
+
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// evictStale removes buckets idle longer than staleAfter. It's gated on
+// lastSweep so a busy store doesn't walk the whole map on every request.
+func (s *memoryStore) evictStale(now time.Time) {
+	if now.Sub(s.lastSweep) < staleAfter {
+		return
+	}
+	s.lastSweep = now
+
+	for key, b := range s.buckets {
+		if now.Sub(b.lastSeen) >= staleAfter {
+			delete(s.buckets, key)
+		}
+	}
+}
+
+func minFloat(a, b float64) float64 {
+	if a < b {
+		return a
+	}
+	return b
+}
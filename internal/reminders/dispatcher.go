@@ -0,0 +1,133 @@
+package reminders
+
+import (
+	"context"
+	"time"
+
+	"github.com/rockswe/justtodothings/db"
+	"github.com/rockswe/justtodothings/internal/logging"
+)
+
+// Config controls how aggressively the dispatcher polls and retries.
+type Config struct {
+	TickInterval time.Duration
+	BatchSize    int
+	MaxAttempts  int
+	// ClaimLease bounds how long a claimed-but-not-yet-resolved reminder
+	// is held out of the candidate set, so a replica that dies mid-send
+	// doesn't strand it forever.
+	ClaimLease time.Duration
+}
+
+// Dispatcher polls for due todos on an interval and delivers them through
+// a Notifier, retrying failures with a capped exponential backoff before
+// giving up and dead-lettering. It implements svc.Service so main can
+// register it alongside the HTTP server.
+type Dispatcher struct {
+	notifier Notifier
+	cfg      Config
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewDispatcher returns a Dispatcher that delivers through notifier
+// according to cfg.
+func NewDispatcher(notifier Notifier, cfg Config) *Dispatcher {
+	return &Dispatcher{
+		notifier: notifier,
+		cfg:      cfg,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// Start launches the poll loop in a goroutine and returns immediately.
+func (d *Dispatcher) Start(ctx context.Context) error {
+	go d.run(ctx)
+	return nil
+}
+
+// Stop signals the poll loop to exit and waits for any in-flight batch to
+// finish sending (or ctx to expire) before returning, so a SIGTERM drains
+// cleanly instead of dropping reminders mid-send.
+func (d *Dispatcher) Stop(ctx context.Context) error {
+	close(d.stop)
+
+	select {
+	case <-d.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (d *Dispatcher) run(ctx context.Context) {
+	defer close(d.done)
+
+	ticker := time.NewTicker(d.cfg.TickInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-d.stop:
+			return
+		case <-ticker.C:
+			d.tick(ctx)
+		}
+	}
+}
+
+func (d *Dispatcher) tick(ctx context.Context) {
+	entry := logging.FromContext(ctx)
+
+	due, err := db.ClaimDueReminders(ctx, d.cfg.BatchSize, d.cfg.ClaimLease)
+	if err != nil {
+		entry.Errorf("reminders: failed to claim due todos: %v", err)
+		return
+	}
+
+	for _, todo := range due {
+		if err := d.notifier.Notify(ctx, todo); err != nil {
+			d.handleFailure(ctx, todo, err)
+			continue
+		}
+
+		if err := db.MarkReminderSent(todo.ID); err != nil {
+			entry.Errorf("reminders: failed to mark todo %d sent: %v", todo.ID, err)
+		}
+	}
+}
+
+// maxBackoff caps the exponential retry delay so a large configured
+// MaxAttempts can't push a retry days out (or overflow the 1<<attempts
+// shift below).
+const maxBackoff = 15 * time.Minute
+
+func (d *Dispatcher) handleFailure(ctx context.Context, todo db.Todo, sendErr error) {
+	entry := logging.FromContext(ctx)
+	attempts := todo.FailedAttempts + 1
+
+	if attempts >= d.cfg.MaxAttempts {
+		entry.Errorf("reminders: todo %d exhausted retries, dead-lettering: %v", todo.ID, sendErr)
+		if err := db.MarkReminderDead(todo.ID); err != nil {
+			entry.Errorf("reminders: failed to dead-letter todo %d: %v", todo.ID, err)
+		}
+		return
+	}
+
+	backoff := maxBackoff
+	// 1<<uint(attempts) overflows once attempts exceeds the shift's bit
+	// width, so only compute it while it's still below the cap.
+	if shift := uint(attempts); shift < 32 {
+		if candidate := time.Duration(1<<shift) * time.Second; candidate < maxBackoff {
+			backoff = candidate
+		}
+	}
+
+	if err := db.ScheduleReminderRetry(todo.ID, attempts, time.Now().Add(backoff)); err != nil {
+		entry.Errorf("reminders: failed to schedule retry for todo %d: %v", todo.ID, err)
+	}
+}
@@ -0,0 +1,24 @@
+package reminders
+
+import (
+	"context"
+	"fmt"
+	"net/smtp"
+
+	"github.com/rockswe/justtodothings/db"
+)
+
+// EmailNotifier delivers reminders over SMTP.
+type EmailNotifier struct {
+	Addr string
+	Auth smtp.Auth
+	From string
+}
+
+// Notify sends a plain-text reminder email to the todo's owner.
+func (n *EmailNotifier) Notify(ctx context.Context, todo db.Todo) error {
+	msg := fmt.Sprintf("To: %s\r\nSubject: Reminder: %s\r\n\r\n\"%s\" is due.\r\n",
+		todo.OwnerEmail, todo.Title, todo.Title)
+
+	return smtp.SendMail(n.Addr, n.Auth, n.From, []string{todo.OwnerEmail}, []byte(msg))
+}
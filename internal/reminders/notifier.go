@@ -0,0 +1,17 @@
+// Package reminders polls for todos whose reminder is due and dispatches
+// them through a pluggable Notifier. It implements svc.Service so main
+// starts and stops it alongside the HTTP server, draining in-flight sends
+// before the process exits.
+package reminders
+
+import (
+	"context"
+
+	"github.com/rockswe/justtodothings/db"
+)
+
+// Notifier delivers a single due reminder. The dispatcher calls Notify
+// once per claimed todo, one at a time, within each tick.
+type Notifier interface {
+	Notify(ctx context.Context, todo db.Todo) error
+}
@@ -0,0 +1,63 @@
+package reminders
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/rockswe/justtodothings/db"
+)
+
+// defaultClientTimeout bounds a WebhookNotifier's HTTP client when none is
+// given. Ticks are serialized in the dispatcher's run loop, so a hung or
+// slow endpoint with no timeout would stall the whole poll loop (and
+// graceful drain) indefinitely.
+const defaultClientTimeout = 10 * time.Second
+
+// WebhookNotifier POSTs a JSON payload describing the due todo to URL.
+// Client should be constructed with an explicit Timeout; a nil Client
+// falls back to defaultClientTimeout rather than http.DefaultClient's
+// unbounded one.
+type WebhookNotifier struct {
+	URL    string
+	Client *http.Client
+}
+
+// Notify POSTs todo's details to the configured webhook, treating any
+// non-2xx response as a delivery failure.
+func (n *WebhookNotifier) Notify(ctx context.Context, todo db.Todo) error {
+	body, err := json.Marshal(map[string]any{
+		"todo_id":   todo.ID,
+		"title":     todo.Title,
+		"remind_at": todo.RemindAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := n.Client
+	if client == nil {
+		client = &http.Client{Timeout: defaultClientTimeout}
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("reminders: webhook returned status %d", resp.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+// Package svc defines the small contract background subsystems (reminder
+// dispatch, soft-delete cleanup, OAuth token refreshers, ...) implement so
+// main can start and stop them alongside the HTTP server in a single,
+// ordered shutdown sequence.
+package svc
+
+import (
+	"context"
+	"log"
+)
+
+// Service is a background subsystem that runs for the lifetime of the
+// process. Start should return once the subsystem is up (it is expected
+// to launch its own goroutines for ongoing work); Stop should block until
+// the subsystem has released its resources or ctx is done.
+type Service interface {
+	Start(ctx context.Context) error
+	Stop(ctx context.Context) error
+}
+
+// Registry starts and stops a fixed set of Services. Services are started
+// in registration order and stopped in reverse order, so a subsystem never
+// outlives something it depends on.
+type Registry struct {
+	services []Service
+}
+
+// NewRegistry returns an empty Registry ready to have services registered.
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Register adds s to the registry. It must be called before StartAll.
+func (r *Registry) Register(s Service) {
+	r.services = append(r.services, s)
+}
+
+// StartAll starts every registered service in order, stopping at (and
+// returning) the first error.
+func (r *Registry) StartAll(ctx context.Context) error {
+	for _, s := range r.services {
+		if err := s.Start(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// StopAll stops every registered service in reverse order. Errors are
+// logged rather than returned so one misbehaving subsystem doesn't stop
+// the rest of shutdown from running.
+func (r *Registry) StopAll(ctx context.Context) {
+	for i := len(r.services) - 1; i >= 0; i-- {
+		if err := r.services[i].Stop(ctx); err != nil {
+			log.Printf("svc: error stopping service: %v", err)
+		}
+	}
+}
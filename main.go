@@ -1,35 +1,134 @@
 package main
 
 import (
-	"log"
+	"context"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/rockswe/justtodothings/db" // Update the module name as needed
+	"github.com/rockswe/justtodothings/internal/auth"
+	"github.com/rockswe/justtodothings/internal/config"
+	"github.com/rockswe/justtodothings/internal/logging"
+	"github.com/rockswe/justtodothings/internal/middleware"
+	"github.com/rockswe/justtodothings/internal/reminders"
+	"github.com/rockswe/justtodothings/internal/svc"
 
 	"github.com/gin-gonic/gin" // Web framework for handling HTTP requests
-	"github.com/joho/godotenv" // Library for loading environment variables
+	"github.com/sirupsen/logrus"
 )
 
 func main() {
-	err := godotenv.Load()
-
+	cfg, err := config.Load()
 	if err != nil {
-		log.Fatalf("Failed to load .env file")
+		logrus.Fatalf("failed to load configuration: %v", err)
 	}
 
-	db.ConnectDatabase()
+	logger := logging.New(cfg.LogLevel, cfg.LogFormat)
+
+	gin.SetMode(cfg.GinMode)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	db.ConnectDatabase(ctx, cfg.DatabaseURL)
+
+	router := gin.New()
+	router.Use(logging.RequestLogger(logger), gin.Recovery())
+
+	limiterStore := middleware.DefaultStore()
+	router.Use(
+		middleware.SecurityHeaders(middleware.SecurityHeadersConfig{ContentSecurityPolicy: cfg.ContentSecurityPolicy}),
+		middleware.CORS(cfg.AllowedOrigins),
+		middleware.RateLimiter(limiterStore, "global", middleware.RateLimitConfig{Limit: cfg.RateLimit, Window: cfg.RateLimitWindow}),
+	)
 
-	router := gin.Default()
+	issuer := auth.NewIssuer(cfg.JWTSecret, cfg.JWTTTL)
 
-	router.GET("/health", func(c *gin.Context) {
+	// registerLimit and loginLimit are tighter than the global default to
+	// slow down credential-stuffing attempts; each gets its own scope so
+	// the two routes don't drain a shared counter (and double-count
+	// against the global limiter's bucket).
+	registerLimit := middleware.RateLimiter(limiterStore, "auth:register", middleware.RateLimitConfig{Limit: 5, Window: time.Minute})
+	loginLimit := middleware.RateLimiter(limiterStore, "auth:login", middleware.RateLimitConfig{Limit: 5, Window: time.Minute})
+
+	public := router.Group("/")
+	public.GET("/health", func(c *gin.Context) {
 		c.JSON(200, gin.H{
 			"message": "Server is running",
 		})
 	})
+	public.POST("/auth/register", registerLimit, auth.RegisterHandler(issuer, cfg.RefreshTTL))
+	public.POST("/auth/login", loginLimit, auth.LoginHandler(issuer, cfg.RefreshTTL))
+	public.POST("/auth/refresh", auth.RefreshHandler(issuer, cfg.RefreshTTL))
 
-	// Start the server on port 8080
-	err = router.Run(":8080")
-	if err != nil {
-		log.Fatalf("Error starting the server: %v", err)
+	authenticated := router.Group("/")
+	authenticated.Use(auth.Required(issuer))
+	authenticated.POST("/auth/logout", auth.LogoutHandler())
+	authenticated.Group("/todos") // reserved for todo handlers
+
+	srv := &http.Server{
+		Addr:    cfg.Listen,
+		Handler: router,
+	}
+
+	var notifier reminders.Notifier
+	switch cfg.ReminderNotifier {
+	case "webhook":
+		notifier = &reminders.WebhookNotifier{
+			URL:    cfg.ReminderWebhookURL,
+			Client: &http.Client{Timeout: cfg.ReminderWebhookTimeout},
+		}
+	default:
+		notifier = &reminders.EmailNotifier{Addr: cfg.SMTPAddr, From: cfg.SMTPFrom}
+	}
+
+	dispatcher := reminders.NewDispatcher(notifier, reminders.Config{
+		TickInterval: cfg.ReminderTickInterval,
+		BatchSize:    cfg.ReminderBatchSize,
+		MaxAttempts:  cfg.ReminderMaxAttempts,
+		ClaimLease:   cfg.ReminderClaimLease,
+	})
+
+	// registry holds background subsystems (reminder dispatcher,
+	// soft-delete cleanup, OAuth token refreshers, ...) that start
+	// alongside the HTTP server and are drained in order on shutdown.
+	registry := svc.NewRegistry()
+	registry.Register(dispatcher)
+	if err := registry.StartAll(ctx); err != nil {
+		logger.Fatalf("failed to start background services: %v", err)
+	}
+
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			logger.Fatalf("error starting the server: %v", err)
+		}
+	}()
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+	logger.Info("shutdown signal received")
+
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer shutdownCancel()
+
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		logger.Errorf("server forced to shutdown: %v", err)
+	}
+
+	// registry.StopAll must run before cancel(): the dispatcher's poll
+	// loop still runs under the root ctx, and cancelling it early would
+	// tear down an in-flight notifier.Notify send instead of letting it
+	// drain.
+	registry.StopAll(shutdownCtx)
+	cancel()
+
+	if err := db.Close(); err != nil {
+		logger.Errorf("error closing database: %v", err)
 	}
 
+	logger.Info("shutdown complete")
 }